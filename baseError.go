@@ -1,13 +1,12 @@
 package baseError
 
 import (
+	"errors"
 	"fmt"
-	"github.com/pkg/errors"
 	"io"
 	"path/filepath"
 	"reflect"
 	"runtime"
-	"strings"
 )
 
 var sourceDir string
@@ -47,6 +46,8 @@ type Error struct {
 	Chain  []string `json:"-"`
 	cause  error
 	*stack
+	maxCauseDepth int
+	msgArgs       []any
 }
 
 func (b *Error) WithCode(code string) *Error {
@@ -60,6 +61,7 @@ func (b *Error) WithMsg(msg string) *Error {
 }
 
 func (b *Error) WithMsgArgs(args ...any) *Error {
+	b.msgArgs = args
 	b.Msg = fmt.Sprintf(b.Msg, args...)
 	return b
 }
@@ -138,15 +140,88 @@ func (b *Error) Unwrap() error {
 	return b.cause
 }
 
+// Is implements the errors.Is interface. Two *Error values are considered
+// equal when their Code fields match; if both Codes are empty, it falls
+// back to comparing Msg so uncoded errors still support matching by
+// message.
+func (b *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if b.Code != "" || t.Code != "" {
+		return b.Code == t.Code
+	}
+	return b.Msg == t.Msg
+}
+
+// As implements the errors.As interface, assigning b into target when
+// target is a **Error.
+func (b *Error) As(target any) bool {
+	t, ok := target.(**Error)
+	if !ok {
+		return false
+	}
+	*t = b
+	return true
+}
+
+// walkErrors visits every *Error reachable from err, following both
+// single-error Unwrap() error chains and Go 1.20+ multi-error
+// Unwrap() []error trees (e.g. *MultiError), stopping as soon as visit
+// returns true.
+func walkErrors(err error, visit func(*Error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if b, ok := err.(*Error); ok && visit(b) {
+		return true
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(x.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if walkErrors(e, visit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasCode reports whether err, or any error reachable from it by unwrapping
+// (including through a *MultiError's per-branch errors), is a *Error whose
+// Code matches code.
+func HasCode(err error, code string) bool {
+	return walkErrors(err, func(b *Error) bool {
+		return b.Code == code
+	})
+}
+
+// GetCode walks err the same way HasCode does and returns the Code of the
+// first *Error found, along with true. If no *Error is found, it returns an
+// empty string and false.
+func GetCode(err error) (string, bool) {
+	var code string
+	var found bool
+	walkErrors(err, func(b *Error) bool {
+		code, found = b.Code, true
+		return true
+	})
+	return code, found
+}
+
 type Option func(*ErrorOption)
 type ErrorOption struct {
-	code    string
-	msg     string
-	system  bool
-	chain   []string
-	cause   error
-	depth   int
-	msgArgs []any
+	code          string
+	msg           string
+	system        bool
+	chain         []string
+	cause         error
+	depth         int
+	msgArgs       []any
+	maxCauseDepth int
 }
 
 func WithCode(code string) Option {
@@ -190,6 +265,25 @@ func WithCause(cause error) Option {
 	}
 }
 
+// WithWrapf behaves like fmt.Errorf: format may contain a %w verb, and the
+// resulting *Error gets both its Msg (the formatted string) and its cause
+// (the %w-wrapped error, via errors.Unwrap) set in one shot.
+func WithWrapf(format string, args ...any) Option {
+	return func(opts *ErrorOption) {
+		wrapped := fmt.Errorf(format, args...)
+		opts.msg = wrapped.Error()
+		opts.cause = errors.Unwrap(wrapped)
+	}
+}
+
+// WithMaxCauseDepth caps how many nested causes MarshalJSON and
+// MarshalLogObject will recurse into, guarding against cyclic causes.
+func WithMaxCauseDepth(n int) Option {
+	return func(opts *ErrorOption) {
+		opts.maxCauseDepth = n
+	}
+}
+
 func WithStack(depth ...int) Option {
 	return func(opts *ErrorOption) {
 		var d = 3
@@ -256,11 +350,13 @@ func Clone(err *Error, opts ...Option) *Error {
 		return nil
 	}
 	e := &Error{
-		Code:   err.Code,
-		Msg:    err.Msg,
-		System: err.System,
-		Chain:  err.Chain,
-		cause:  err.cause,
+		Code:          err.Code,
+		Msg:           err.Msg,
+		System:        err.System,
+		Chain:         err.Chain,
+		cause:         err.cause,
+		maxCauseDepth: err.maxCauseDepth,
+		msgArgs:       err.msgArgs,
 	}
 	return ApplyOption(e, opts...)
 }
@@ -293,7 +389,11 @@ func ApplyOption(err *Error, opts ...Option) *Error {
 	if errOpt.depth != 0 {
 		err.stack = callers(3, errOpt.depth)
 	}
+	if errOpt.maxCauseDepth != 0 {
+		err.maxCauseDepth = errOpt.maxCauseDepth
+	}
 	if len(errOpt.msgArgs) > 0 {
+		err.msgArgs = errOpt.msgArgs
 		err.Msg = fmt.Sprintf(err.Msg, errOpt.msgArgs...)
 	}
 	return err
@@ -301,37 +401,35 @@ func ApplyOption(err *Error, opts ...Option) *Error {
 
 type stack []uintptr
 
+// StackTrace is a resolved stack, one runtime.Frame per call as reported by
+// runtime.CallersFrames (an inlined call gets its own frame, so there can be
+// more frames than captured program counters), innermost call first.
+type StackTrace []runtime.Frame
+
 func (s *stack) Format(st fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		switch {
 		case st.Flag('+'):
-			for _, pc := range *s {
-				f := errors.Frame(pc)
-				fmt.Fprintf(st, "\n%+v", f)
+			for _, f := range s.StackTrace() {
+				fmt.Fprintf(st, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
 			}
 		}
 	}
 }
-func (s *stack) StackTrace() errors.StackTrace {
-	f := make([]errors.Frame, len(*s))
-	for i := 0; i < len(f); i++ {
-		f[i] = errors.Frame((*s)[i])
-	}
-	return f
-}
 
-func callers(skip int, depth int) *stack {
-	var s = skip
-	for i := skip; i < 15; i++ {
-		_, file, _, ok := runtime.Caller(i)
-		if ok && (!strings.HasPrefix(file, sourceDir) || strings.HasSuffix(file, "_test.go")) {
-			s = i + 1
+// StackTrace resolves s's raw program counters into frames via
+// runtime.CallersFrames.
+func (s *stack) StackTrace() StackTrace {
+	frames := runtime.CallersFrames(*s)
+	st := make(StackTrace, 0, len(*s))
+	for {
+		f, more := frames.Next()
+		st = append(st, f)
+		if !more {
 			break
 		}
 	}
-	pcs := make([]uintptr, depth)
-	n := runtime.Callers(s, pcs[:])
-	var st stack = pcs[0:n]
-	return &st
+	return st
 }
+
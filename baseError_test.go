@@ -1,6 +1,8 @@
 package baseError
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -9,3 +11,35 @@ func TestNewBaseError(t *testing.T) {
 	e1 := Clone(err, WithMsgArgs(1, "e1"))
 	t.Log(e1)
 }
+
+func TestIsAndCode(t *testing.T) {
+	sentinel := NewCode("NOT_FOUND", "not found")
+	wrapped := fmt.Errorf("load user: %w", NewCodeWrap("NOT_FOUND", errors.New("db miss")))
+
+	if !errors.Is(wrapped, sentinel) {
+		t.Fatal("expected errors.Is to match through fmt.Errorf wrap")
+	}
+	if !HasCode(wrapped, "NOT_FOUND") {
+		t.Fatal("expected HasCode to find code through wrap chain")
+	}
+	if code, ok := GetCode(wrapped); !ok || code != "NOT_FOUND" {
+		t.Fatalf("GetCode = %q, %v", code, ok)
+	}
+
+	var target *Error
+	if !errors.As(wrapped, &target) {
+		t.Fatal("expected errors.As to assign *Error through wrap chain")
+	}
+}
+
+func TestWithWrapf(t *testing.T) {
+	cause := errors.New("conn refused")
+	err := New("", WithWrapf("dial db: %w", cause))
+
+	if err.Msg != "dial db: conn refused" {
+		t.Fatalf("unexpected msg: %q", err.Msg)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatal("expected errors.Is to find cause set by WithWrapf")
+	}
+}
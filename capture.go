@@ -0,0 +1,84 @@
+package baseError
+
+import (
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// stackSampler, when set via SetStackSampler, gates whether callers()
+// captures a stack at all. nil (the default) means always capture. Held in
+// an atomic.Value since it's read from every WithStack() call and may be
+// reconfigured concurrently.
+var stackSampler atomic.Value // func() bool
+
+// SetStackSampler lets high-QPS callers capture stacks probabilistically
+// (e.g. sample() returning true ~1% of the time) instead of on every
+// WithStack()/WithLazyStack() call, to bound the cost of error construction
+// on the request path. Pass nil to go back to capturing unconditionally.
+func SetStackSampler(sample func() bool) {
+	stackSampler.Store(sample)
+}
+
+func loadStackSampler() func() bool {
+	f, _ := stackSampler.Load().(func() bool)
+	return f
+}
+
+// sourceDirFilter, when set via SetSourceDirFilter, replaces the default
+// strings.HasPrefix(file, sourceDir) heuristic used by callers() to decide
+// which frames belong to this package and should be skipped. Held in an
+// atomic.Value for the same reason as stackSampler.
+var sourceDirFilter atomic.Value // func(string) bool
+
+// SetSourceDirFilter overrides how callers() decides whether a frame's file
+// belongs to this package. The default strings.HasPrefix(file, sourceDir)
+// heuristic breaks when this module is vendored or built with -trimpath;
+// f should return true when file is considered part of this errors
+// package. Pass nil to restore the default heuristic.
+func SetSourceDirFilter(f func(file string) bool) {
+	sourceDirFilter.Store(f)
+}
+
+func loadSourceDirFilter() func(string) bool {
+	f, _ := sourceDirFilter.Load().(func(string) bool)
+	return f
+}
+
+func callers(skip int, depth int) *stack {
+	if sample := loadStackSampler(); sample != nil && !sample() {
+		return nil
+	}
+
+	filter := loadSourceDirFilter()
+	var s = skip
+	for i := skip; i < 15; i++ {
+		_, file, _, ok := runtime.Caller(i)
+		inPkg := strings.HasPrefix(file, sourceDir)
+		if filter != nil {
+			inPkg = filter(file)
+		}
+		if ok && (!inPkg || strings.HasSuffix(file, "_test.go")) {
+			s = i + 1
+			break
+		}
+	}
+
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(s, pcs[:])
+	var st stack = pcs[0:n]
+	return &st
+}
+
+// WithLazyStack is an alias for WithStack: frame resolution (func/file/line)
+// is already deferred until Format, MarshalJSON or MarshalLogObject is
+// invoked, since *stack only ever holds raw program counters. It exists so
+// call sites can name that deferred-cost intent explicitly.
+func (b *Error) WithLazyStack(depth ...int) *Error {
+	return b.WithStack(depth...)
+}
+
+// WithLazyStack is the Option form of (*Error).WithLazyStack.
+func WithLazyStack(depth ...int) Option {
+	return WithStack(depth...)
+}
@@ -0,0 +1,37 @@
+package baseError
+
+import "testing"
+
+func TestStackSampler(t *testing.T) {
+	defer SetStackSampler(nil)
+
+	SetStackSampler(func() bool { return false })
+	if err := New("skipped", WithStack()); err.Stack() != nil {
+		t.Fatal("expected stack to be nil when sampler rejects capture")
+	}
+
+	SetStackSampler(func() bool { return true })
+	if err := New("captured", WithStack()); err.Stack() == nil {
+		t.Fatal("expected stack to be captured when sampler allows it")
+	}
+}
+
+func TestSourceDirFilter(t *testing.T) {
+	defer SetSourceDirFilter(nil)
+
+	SetSourceDirFilter(func(file string) bool { return false })
+	err := New("custom filter", WithStack())
+	if err.Stack() == nil || len(*err.Stack()) == 0 {
+		t.Fatal("expected a captured stack with a custom source dir filter")
+	}
+}
+
+func TestWithLazyStack(t *testing.T) {
+	err := NewCode("LAZY", "lazy stack", WithLazyStack())
+	if err.Stack() == nil {
+		t.Fatal("expected WithLazyStack to capture raw program counters")
+	}
+	if frames := err.Stack().StackTrace(); len(frames) == 0 {
+		t.Fatal("expected frame resolution to work on demand")
+	}
+}
@@ -0,0 +1,105 @@
+package baseError
+
+import (
+	"fmt"
+	"sync"
+)
+
+// catalogEntry is the registered template for one error code: the raw
+// per-language message templates plus a fully-built template *Error (in
+// the "en" language) that Register's factory clones from.
+type catalogEntry struct {
+	code      string
+	templates map[string]string
+	base      *Error
+}
+
+// Catalog lets an application declare its error codes once, at init time,
+// instead of scattering ad-hoc NewCode calls across the codebase, and
+// centralizes their i18n message templates.
+type Catalog struct {
+	mu      sync.Mutex
+	entries map[string]*catalogEntry
+	codes   []string
+}
+
+// NewCatalog returns an empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{entries: make(map[string]*catalogEntry)}
+}
+
+// Register declares code with its per-language message templates (keyed by
+// language tag, e.g. "en", "zh") and returns a factory that builds a *Error
+// for code, formatting the "en" template with args via WithMsgArgs. opts
+// are applied once, to the template *Error that the factory clones; the
+// clone keeps the template's Code, Msg, System, Chain, cause and max cause
+// depth, but not a stack captured by WithStack.
+func (c *Catalog) Register(code string, templates map[string]string, opts ...Option) func(args ...any) *Error {
+	base := NewCode(code, templates["en"], opts...)
+
+	c.mu.Lock()
+	c.codes = append(c.codes, code)
+	c.entries[code] = &catalogEntry{code: code, templates: templates, base: base}
+	c.mu.Unlock()
+
+	return func(args ...any) *Error {
+		e := base.Clone()
+		if len(args) > 0 {
+			e.WithMsgArgs(args...)
+		}
+		return e
+	}
+}
+
+// Localize rewrites err.Msg using the template registered for err.Code in
+// lang (falling back to "en" if lang has no template), reapplying err's
+// original format args. If err.Code was never registered, err is returned
+// unchanged.
+func (c *Catalog) Localize(err *Error, lang string) *Error {
+	c.mu.Lock()
+	entry, ok := c.entries[err.Code]
+	c.mu.Unlock()
+	if !ok {
+		return err
+	}
+
+	tmpl, ok := entry.templates[lang]
+	if !ok {
+		tmpl = entry.templates["en"]
+	}
+
+	out := err.Clone()
+	out.Msg = tmpl
+	if len(err.msgArgs) > 0 {
+		out.WithMsgArgs(err.msgArgs...)
+	}
+	return out
+}
+
+// Validate reports an error if the same code was registered more than once.
+func (c *Catalog) Validate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]bool, len(c.codes))
+	for _, code := range c.codes {
+		if seen[code] {
+			return fmt.Errorf("baseError: duplicate catalog code %q", code)
+		}
+		seen[code] = true
+	}
+	return nil
+}
+
+// All returns one template *Error per registered code, for exporting a
+// codebook.
+func (c *Catalog) All() []*Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all := make([]*Error, 0, len(c.entries))
+	for _, entry := range c.entries {
+		all = append(all, entry.base.Clone())
+	}
+	return all
+}
@@ -0,0 +1,40 @@
+package baseError
+
+import "testing"
+
+func TestCatalogLocalize(t *testing.T) {
+	cat := NewCatalog()
+	ErrUserNotFound := cat.Register("USER_404", map[string]string{
+		"en": "user %s not found",
+		"zh": "用户 %s 未找到",
+	}, WithSystem())
+
+	err := ErrUserNotFound("bob")
+	if err.Msg != "user bob not found" {
+		t.Fatalf("unexpected msg: %q", err.Msg)
+	}
+	if !err.System {
+		t.Fatal("expected WithSystem option to apply")
+	}
+
+	zh := cat.Localize(err, "zh")
+	if zh.Msg != "用户 bob 未找到" {
+		t.Fatalf("unexpected localized msg: %q", zh.Msg)
+	}
+	if err.Msg != "user bob not found" {
+		t.Fatal("Localize must not mutate the original error")
+	}
+
+	if validateErr := cat.Validate(); validateErr != nil {
+		t.Fatalf("unexpected validate error: %v", validateErr)
+	}
+
+	cat.Register("USER_404", map[string]string{"en": "dup"})
+	if validateErr := cat.Validate(); validateErr == nil {
+		t.Fatal("expected Validate to catch duplicate code")
+	}
+
+	if len(cat.All()) != 1 {
+		t.Fatalf("expected 1 distinct code in codebook, got %d", len(cat.All()))
+	}
+}
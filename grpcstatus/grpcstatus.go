@@ -0,0 +1,113 @@
+// Package grpcstatus bridges baseError.Error and google.rpc.Status so a
+// *baseError.Error returned by a gRPC server can be reconstructed, with its
+// Code, Chain and cause intact, on the client side of the call.
+package grpcstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	baseError "github.com/go-estar/base-error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeMapper maps a baseError.Error.Code to a grpc codes.Code.
+type CodeMapper func(code string) codes.Code
+
+// DefaultCodeMapper maps every code to codes.Unknown; ToStatus upgrades
+// this to codes.Internal when the error is a system error.
+var DefaultCodeMapper CodeMapper = func(string) codes.Code {
+	return codes.Unknown
+}
+
+// ToStatus converts err into a *status.Status. If err is not a
+// *baseError.Error it is wrapped first via baseError.NewWrap. The grpc
+// code is resolved via mapper (DefaultCodeMapper if nil); the original
+// Code/Msg/Chain are attached as an ErrorInfo detail (carrying the full
+// MarshalJSON document so FromStatus can rebuild the cause chain), and the
+// captured stack, if any, is attached as a DebugInfo detail.
+func ToStatus(err error, mapper CodeMapper) *status.Status {
+	if err == nil {
+		return nil
+	}
+	be, ok := err.(*baseError.Error)
+	if !ok {
+		be = baseError.NewWrap(err)
+	}
+	if mapper == nil {
+		mapper = DefaultCodeMapper
+	}
+	grpcCode := mapper(be.Code)
+	if grpcCode == codes.Unknown && be.System {
+		grpcCode = codes.Internal
+	}
+
+	s := status.New(grpcCode, be.Msg)
+
+	if doc, marshalErr := json.Marshal(be); marshalErr == nil {
+		info := &errdetails.ErrorInfo{
+			Reason:   be.Code,
+			Metadata: map[string]string{"doc": string(doc)},
+		}
+		if withDetails, detailErr := s.WithDetails(info); detailErr == nil {
+			s = withDetails
+		}
+	}
+
+	if be.Stack() != nil {
+		debug := &errdetails.DebugInfo{}
+		for _, f := range be.Stack().StackTrace() {
+			debug.StackEntries = append(debug.StackEntries, fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line))
+		}
+		if withDetails, detailErr := s.WithDetails(debug); detailErr == nil {
+			s = withDetails
+		}
+	}
+
+	return s
+}
+
+// FromStatus reverses ToStatus, reconstructing a *baseError.Error from the
+// ErrorInfo detail on s. When s carries no ErrorInfo, it falls back to a
+// plain *baseError.Error built from s.Message().
+func FromStatus(s *status.Status) *baseError.Error {
+	if s == nil {
+		return nil
+	}
+	for _, d := range s.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		if doc, ok := info.Metadata["doc"]; ok {
+			be := &baseError.Error{}
+			if err := json.Unmarshal([]byte(doc), be); err == nil {
+				return be
+			}
+		}
+		return baseError.NewCode(info.Reason, s.Message())
+	}
+	return baseError.New(s.Message())
+}
+
+// UnaryServerInterceptor converts a *baseError.Error returned by a unary
+// handler into a *status.Status via ToStatus, using mapper to resolve grpc
+// codes (DefaultCodeMapper if nil). Errors that aren't a *baseError.Error
+// (including an existing *status.Status) are passed through unchanged.
+func UnaryServerInterceptor(mapper CodeMapper) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		be, ok := err.(*baseError.Error)
+		if !ok {
+			return resp, err
+		}
+		return resp, ToStatus(be, mapper).Err()
+	}
+}
@@ -0,0 +1,47 @@
+package grpcstatus
+
+import (
+	"context"
+	"testing"
+
+	baseError "github.com/go-estar/base-error"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRoundTrip(t *testing.T) {
+	src := baseError.NewSystemCode("USER_404", "user not found", baseError.WithChain("svc", "repo"), baseError.WithStack())
+
+	mapper := func(code string) codes.Code {
+		if code == "USER_404" {
+			return codes.NotFound
+		}
+		return codes.Unknown
+	}
+
+	s := ToStatus(src, mapper)
+	if s.Code() != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", s.Code())
+	}
+
+	got := FromStatus(s)
+	if got.Code != src.Code || got.Msg != src.Msg {
+		t.Fatalf("round trip mismatch: got %+v", got)
+	}
+	if len(got.Chain) != 2 || got.Chain[0] != "svc" {
+		t.Fatalf("chain did not round-trip: %v", got.Chain)
+	}
+}
+
+func TestUnaryServerInterceptorPassesThroughNonBaseErrors(t *testing.T) {
+	interceptor := UnaryServerInterceptor(nil)
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.PermissionDenied, "no access")
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected existing status to pass through unchanged, got %v", status.Code(err))
+	}
+}
@@ -0,0 +1,143 @@
+package baseError
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultMaxCauseDepth bounds cause-chain recursion in MarshalJSON and
+// MarshalLogObject when no WithMaxCauseDepth option was set, guarding
+// against cyclic or pathologically deep causes.
+const defaultMaxCauseDepth = 10
+
+// frame is the JSON/log representation of a single stack frame.
+type frame struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+func frameOf(f runtime.Frame) frame {
+	return frame{
+		Func: f.Function,
+		File: f.File,
+		Line: f.Line,
+	}
+}
+
+// errorDoc is the structured document produced by MarshalJSON. Cause is
+// recursive: if the cause is itself a *Error it nests as another errorDoc,
+// otherwise it is rendered as a terminal {"msg": err.Error()}.
+type errorDoc struct {
+	Code   string    `json:"code,omitempty"`
+	Msg    string    `json:"msg"`
+	System bool      `json:"system,omitempty"`
+	Chain  []string  `json:"chain,omitempty"`
+	Stack  []frame   `json:"stack,omitempty"`
+	Cause  *errorDoc `json:"cause,omitempty"`
+}
+
+// causeDepthLimit returns the cap set on b by WithMaxCauseDepth, or
+// defaultMaxCauseDepth when none was set.
+func (b *Error) causeDepthLimit() int {
+	if b.maxCauseDepth <= 0 {
+		return defaultMaxCauseDepth
+	}
+	return b.maxCauseDepth
+}
+
+func (b *Error) toDoc(depth, maxDepth int) *errorDoc {
+	doc := &errorDoc{Code: b.Code, Msg: b.Msg, System: b.System, Chain: b.Chain}
+	if b.stack != nil {
+		st := b.stack.StackTrace()
+		doc.Stack = make([]frame, len(st))
+		for i, f := range st {
+			doc.Stack[i] = frameOf(f)
+		}
+	}
+	if b.cause != nil && depth < maxDepth {
+		if be, ok := b.cause.(*Error); ok {
+			doc.Cause = be.toDoc(depth+1, maxDepth)
+		} else {
+			doc.Cause = &errorDoc{Msg: b.cause.Error()}
+		}
+	}
+	return doc
+}
+
+func (b *Error) fromDoc(doc *errorDoc) {
+	b.Code = doc.Code
+	b.Msg = doc.Msg
+	b.System = doc.System
+	b.Chain = doc.Chain
+	if doc.Cause != nil {
+		cause := &Error{}
+		cause.fromDoc(doc.Cause)
+		b.cause = cause
+	}
+}
+
+// MarshalJSON renders b as a structured document containing code, msg,
+// system, chain, the captured stack frames and a recursive cause chain,
+// for use by structured logging and RPC boundaries.
+func (b *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.toDoc(0, b.causeDepthLimit()))
+}
+
+// UnmarshalJSON reconstructs an *Error from the document produced by
+// MarshalJSON. Stack frames are not restored (program counters cannot be
+// replayed across processes); Code, Msg, System, Chain and the cause chain
+// round-trip.
+func (b *Error) UnmarshalJSON(data []byte) error {
+	doc := &errorDoc{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return err
+	}
+	b.fromDoc(doc)
+	return nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler so a *Error can be
+// logged with zap.Object/zap.Error-style field encoders and still surface
+// its code, chain, stack and cause.
+func (b *Error) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return b.marshalLogObject(enc, 0, b.causeDepthLimit())
+}
+
+func (b *Error) marshalLogObject(enc zapcore.ObjectEncoder, depth, maxDepth int) error {
+	if b.Code != "" {
+		enc.AddString("code", b.Code)
+	}
+	enc.AddString("msg", b.Msg)
+	if b.System {
+		enc.AddBool("system", b.System)
+	}
+	if len(b.Chain) > 0 {
+		enc.AddString("chain", strings.Join(b.Chain, "/"))
+	}
+	if b.stack != nil {
+		st := b.stack.StackTrace()
+		_ = enc.AddArray("stack", zapcore.ArrayMarshalerFunc(func(aenc zapcore.ArrayEncoder) error {
+			for _, f := range st {
+				fr := frameOf(f)
+				aenc.AppendString(fmt.Sprintf("%s:%d %s", fr.File, fr.Line, fr.Func))
+			}
+			return nil
+		}))
+	}
+
+	if b.cause != nil && depth < maxDepth {
+		if be, ok := b.cause.(*Error); ok {
+			_ = enc.AddObject("cause", zapcore.ObjectMarshalerFunc(func(causeEnc zapcore.ObjectEncoder) error {
+				return be.marshalLogObject(causeEnc, depth+1, maxDepth)
+			}))
+		} else {
+			enc.AddString("cause", b.cause.Error())
+		}
+	}
+	return nil
+}
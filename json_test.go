@@ -0,0 +1,44 @@
+package baseError
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	cause := NewCode("DB_MISS", "row not found")
+	err := NewCodeWrap("NOT_FOUND", cause, WithSystem(), WithChain("svc", "repo"), WithStack())
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("MarshalJSON failed: %v", marshalErr)
+	}
+
+	var out Error
+	if unmarshalErr := json.Unmarshal(data, &out); unmarshalErr != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", unmarshalErr)
+	}
+	if out.Code != err.Code || out.System != err.System {
+		t.Fatalf("round trip mismatch: got %+v", out)
+	}
+	if len(out.Chain) != 2 || out.Chain[0] != "svc" || out.Chain[1] != "repo" {
+		t.Fatalf("chain did not round-trip: %v", out.Chain)
+	}
+	if c, ok := out.cause.(*Error); !ok || c.Code != "DB_MISS" {
+		t.Fatalf("cause did not round-trip: %+v", out.cause)
+	}
+}
+
+func TestMarshalLogObjectCyclicCauseIsBounded(t *testing.T) {
+	a := NewCode("A", "a")
+	b := NewCode("B", "b")
+	a.WithCause(b)
+	b.WithCause(a)
+
+	enc := zapcore.NewMapObjectEncoder()
+	if err := a.MarshalLogObject(enc); err != nil {
+		t.Fatalf("MarshalLogObject failed: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package baseError
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MultiError aggregates the failures of several independent operations
+// (parallel goroutines, batched RPCs) into a single error value, preserving
+// each branch's own Code, Chain and stack, which a single *Error with one
+// cause cannot express.
+type MultiError struct {
+	errs []*Error
+}
+
+// Join coerces every non-nil err into a *Error (via NewWrap when it isn't
+// one already) and returns a *MultiError holding them. Join never returns
+// nil; use ErrorOrNil to get a nil error when there were no failures.
+func Join(errs ...error) *MultiError {
+	m := &MultiError{}
+	for _, err := range errs {
+		m.Append(err)
+	}
+	return m
+}
+
+// Append adds err to m, coercing it to *Error via NewWrap if needed. A nil
+// err is a no-op.
+func (m *MultiError) Append(err error) {
+	if err == nil {
+		return
+	}
+	if be, ok := err.(*Error); ok {
+		m.errs = append(m.errs, be)
+		return
+	}
+	m.errs = append(m.errs, NewWrap(err))
+}
+
+// ErrorOrNil returns m as an error, or nil if m holds no errors. Use this
+// at a function's return site so an empty MultiError doesn't get reported
+// as a failure.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Errors returns the branch errors held by m.
+func (m *MultiError) Errors() []*Error {
+	return m.errs
+}
+
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the branch errors so errors.Is/errors.As (Go 1.20+
+// multi-unwrap) can walk every branch.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.errs))
+	for i, err := range m.errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Is delegates to each branch error, so errors.Is(multi, sentinel) matches
+// if any branch matches.
+func (m *MultiError) Is(target error) bool {
+	for _, err := range m.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As delegates to each branch error, so errors.As(multi, &target) assigns
+// from the first branch that matches.
+func (m *MultiError) As(target any) bool {
+	for _, err := range m.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			for i, err := range m.errs {
+				fmt.Fprintf(s, "\n--- error %d/%d ---\n", i+1, len(m.errs))
+				fmt.Fprintf(s, "%+v", err)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, m.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", m.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package baseError
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	sentinel := NewCode("TIMEOUT", "timed out")
+
+	m := Join(NewCode("BAD_INPUT", "bad input"), sentinel, nil)
+	if len(m.Errors()) != 2 {
+		t.Fatalf("expected 2 branch errors, got %d", len(m.Errors()))
+	}
+	if !errors.Is(m, sentinel) {
+		t.Fatal("expected errors.Is to find the sentinel among branches")
+	}
+
+	var target *Error
+	if !errors.As(m, &target) {
+		t.Fatal("expected errors.As to assign a branch error")
+	}
+
+	empty := Join()
+	if empty.ErrorOrNil() != nil {
+		t.Fatal("expected ErrorOrNil to be nil for an empty MultiError")
+	}
+	if m.ErrorOrNil() == nil {
+		t.Fatal("expected ErrorOrNil to be non-nil when branches exist")
+	}
+}
+
+func TestHasCodeAndGetCodeSeeIntoMultiError(t *testing.T) {
+	m := Join(NewCode("BAD_INPUT", "bad input"), NewCode("TIMEOUT", "timed out"))
+
+	if !HasCode(m, "TIMEOUT") {
+		t.Fatal("expected HasCode to find a code carried by one branch of a MultiError")
+	}
+	if code, ok := GetCode(m); !ok || (code != "BAD_INPUT" && code != "TIMEOUT") {
+		t.Fatalf("GetCode = %q, %v", code, ok)
+	}
+	if HasCode(m, "NOPE") {
+		t.Fatal("expected HasCode to report false for a code no branch carries")
+	}
+}